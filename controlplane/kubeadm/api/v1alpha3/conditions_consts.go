@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+// Conditions and condition Reasons for the KubeadmControlPlane object.
+const (
+	// MachineAPIServerPodHealthyCondition reports the health of the kube-apiserver static pod on a control plane Machine.
+	MachineAPIServerPodHealthyCondition clusterv1.ConditionType = "APIServerPodHealthy"
+
+	// MachineControllerManagerPodHealthyCondition reports the health of the kube-controller-manager static pod on a control plane Machine.
+	MachineControllerManagerPodHealthyCondition clusterv1.ConditionType = "ControllerManagerPodHealthy"
+
+	// MachineSchedulerPodHealthyCondition reports the health of the kube-scheduler static pod on a control plane Machine.
+	MachineSchedulerPodHealthyCondition clusterv1.ConditionType = "SchedulerPodHealthy"
+
+	// MachineEtcdPodHealthyCondition reports the health of the etcd static pod on a control plane Machine.
+	MachineEtcdPodHealthyCondition clusterv1.ConditionType = "EtcdPodHealthy"
+
+	// MachineEtcdMemberHealthyCondition reports the health of the etcd member running on a control plane Machine.
+	MachineEtcdMemberHealthyCondition clusterv1.ConditionType = "EtcdMemberHealthy"
+
+	// WaitingForPodInspectionReason is used when any of the control plane component static pods have not yet reported a status.
+	WaitingForPodInspectionReason = "WaitingForPodInspection"
+
+	// PodNotHealthyReason is used when a control plane component static pod is not ready.
+	PodNotHealthyReason = "PodNotHealthy"
+)
+
+// Condition reasons and types for rollout/upgrade related conditions. These are set on
+// control plane Machines; KubeadmControlPlane status does not carry them.
+const (
+	// KubeadmConfigUpToDateCondition documents whether a control plane Machine's KubeadmConfig
+	// matches the KubeadmControlPlane's desired ClusterConfiguration, or whether a rollout is
+	// required to bring it in line.
+	KubeadmConfigUpToDateCondition clusterv1.ConditionType = "KubeadmConfigUpToDate"
+
+	// KubeadmConfigOutOfDateReason is used when a Machine's KubeadmConfig semantically differs
+	// from the KubeadmControlPlane's desired ClusterConfiguration.
+	KubeadmConfigOutOfDateReason = "KubeadmConfigOutOfDate"
+)
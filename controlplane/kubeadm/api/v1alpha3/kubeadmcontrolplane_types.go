@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+)
+
+// KubeadmControlPlaneSpec defines the desired state of KubeadmControlPlane.
+type KubeadmControlPlaneSpec struct {
+	// Replicas is the number of desired machines. Defaults to 1. When stacked etcd is used only
+	// odd numbers are permitted, as per [etcd best practice](https://etcd.io/docs/v3.3.12/faq/#why-an-odd-number-of-cluster-members).
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Version defines the desired Kubernetes version.
+	Version string `json:"version"`
+
+	// InfrastructureTemplate is a required reference to a custom resource
+	// offered by an infrastructure provider.
+	InfrastructureTemplate corev1.ObjectReference `json:"infrastructureTemplate"`
+
+	// KubeadmConfigSpec is a KubeadmConfigSpec to use for initializing and joining machines to the control plane.
+	KubeadmConfigSpec bootstrapv1.KubeadmConfigSpec `json:"kubeadmConfigSpec"`
+
+	// UpgradeAfter is a field to indicate an upgrade should be performed
+	// after the specified time even if no changes have been made to the
+	// KubeadmControlPlane.
+	// +optional
+	UpgradeAfter *metav1.Time `json:"upgradeAfter,omitempty"`
+
+	// RolloutStrategy describes how replacement of out of date machines should happen.
+	// Defaults to RollingUpdate with MaxSurge of 1.
+	// +optional
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// RolloutHooks is a list of external gRPC FilterService endpoints consulted
+	// before KCP rolls out or remediates a control plane Machine. A hook may
+	// veto the action, letting downstream projects enforce policies such as
+	// maintenance windows or change-management approvals without forking CAPI.
+	// +optional
+	RolloutHooks []RolloutHook `json:"rolloutHooks,omitempty"`
+}
+
+// KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
+type KubeadmControlPlaneStatus struct {
+	// Total number of non-terminated machines targeted by this control plane
+	// (their labels match the selector).
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// Total number of fully running and ready control plane machines.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Total number of unavailable machines targeted by this control plane.
+	// +optional
+	UnavailableReplicas int32 `json:"unavailableReplicas"`
+
+	// Conditions defines current service state of the KubeadmControlPlane.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// KubeadmControlPlane is the Schema for the KubeadmControlPlane API.
+type KubeadmControlPlane struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubeadmControlPlaneSpec   `json:"spec,omitempty"`
+	Status KubeadmControlPlaneStatus `json:"status,omitempty"`
+}
+
+// KubeadmControlPlaneList contains a list of KubeadmControlPlane.
+type KubeadmControlPlaneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubeadmControlPlane `json:"items"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (in *KubeadmControlPlane) GetConditions() clusterv1.Conditions {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (in *KubeadmControlPlane) SetConditions(conditions clusterv1.Conditions) {
+	in.Status.Conditions = conditions
+}
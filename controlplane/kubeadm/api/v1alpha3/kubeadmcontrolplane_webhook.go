@@ -0,0 +1,197 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the KubeadmControlPlane defaulting and
+// validating webhooks with the manager.
+func (in *KubeadmControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(in).
+		Complete()
+}
+
+var _ webhook.Defaulter = &KubeadmControlPlane{}
+var _ webhook.Validator = &KubeadmControlPlane{}
+
+// defaultRolloutStrategy is applied whenever a KubeadmControlPlane does not
+// specify a RolloutStrategy of its own.
+func defaultRolloutStrategy() *RolloutStrategy {
+	one := intstr.FromInt(1)
+	return &RolloutStrategy{
+		Type: RollingUpdateStrategyType,
+		RollingUpdate: &RollingUpdate{
+			MaxSurge: &one,
+		},
+	}
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (in *KubeadmControlPlane) Default() {
+	if in.Spec.Replicas == nil {
+		replicas := int32(1)
+		in.Spec.Replicas = &replicas
+	}
+
+	if in.Spec.RolloutStrategy == nil {
+		in.Spec.RolloutStrategy = defaultRolloutStrategy()
+	}
+
+	if in.Spec.RolloutStrategy.Type == "" {
+		in.Spec.RolloutStrategy.Type = RollingUpdateStrategyType
+	}
+
+	if in.Spec.RolloutStrategy.Type == RollingUpdateStrategyType && in.Spec.RolloutStrategy.RollingUpdate == nil {
+		one := intstr.FromInt(1)
+		in.Spec.RolloutStrategy.RollingUpdate = &RollingUpdate{MaxSurge: &one}
+	}
+
+	if in.Spec.RolloutStrategy.Type == RollingUpdateStrategyType && in.Spec.RolloutStrategy.RollingUpdate.MaxSurge == nil {
+		one := intstr.FromInt(1)
+		in.Spec.RolloutStrategy.RollingUpdate.MaxSurge = &one
+	}
+
+	if in.Spec.RolloutStrategy.Type == CanaryStrategyType && in.Spec.RolloutStrategy.Canary == nil {
+		one := int32(1)
+		in.Spec.RolloutStrategy.Canary = &Canary{Replicas: &one}
+	}
+
+	if in.Spec.RolloutStrategy.Type == CanaryStrategyType && in.Spec.RolloutStrategy.Canary.Replicas == nil {
+		one := int32(1)
+		in.Spec.RolloutStrategy.Canary.Replicas = &one
+	}
+
+	for i := range in.Spec.RolloutHooks {
+		hook := &in.Spec.RolloutHooks[i]
+		if hook.TimeoutSeconds == nil {
+			defaultTimeout := int32(5)
+			hook.TimeoutSeconds = &defaultTimeout
+		}
+		if hook.Default == "" {
+			hook.Default = RolloutHookDefaultDeny
+		}
+	}
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (in *KubeadmControlPlane) ValidateCreate() error {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, in.validateRolloutStrategyErrors()...)
+	allErrs = append(allErrs, in.validateRolloutHooks()...)
+	return aggregateFieldErrors(in, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, in.validateRolloutStrategyErrors()...)
+	allErrs = append(allErrs, in.validateRolloutHooks()...)
+	return aggregateFieldErrors(in, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (in *KubeadmControlPlane) ValidateDelete() error {
+	return nil
+}
+
+func (in *KubeadmControlPlane) validateRolloutStrategyErrors() field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec", "rolloutStrategy")
+
+	strategy := in.Spec.RolloutStrategy
+	if strategy == nil {
+		return allErrs
+	}
+
+	switch strategy.Type {
+	case RollingUpdateStrategyType:
+		if strategy.Canary != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("canary"), "must not be set when type is RollingUpdate"))
+		}
+		if strategy.RollingUpdate != nil && strategy.RollingUpdate.MaxSurge != nil && strategy.RollingUpdate.MaxUnavailable != nil {
+			if maxSurgeZero(strategy.RollingUpdate.MaxSurge) && maxSurgeZero(strategy.RollingUpdate.MaxUnavailable) {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("rollingUpdate"), strategy.RollingUpdate,
+					"may not be 0 for both maxSurge and maxUnavailable"))
+			}
+		}
+	case CanaryStrategyType:
+		if strategy.RollingUpdate != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("rollingUpdate"), "must not be set when type is Canary"))
+		}
+		if strategy.Canary != nil && strategy.Canary.Replicas != nil && *strategy.Canary.Replicas < 1 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("canary", "replicas"), *strategy.Canary.Replicas,
+				"must be greater than 0"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("type"), strategy.Type,
+			[]string{string(RollingUpdateStrategyType), string(CanaryStrategyType)}))
+	}
+
+	return allErrs
+}
+
+func (in *KubeadmControlPlane) validateRolloutHooks() field.ErrorList {
+	var allErrs field.ErrorList
+	hooksPath := field.NewPath("spec", "rolloutHooks")
+
+	seenNames := sets.NewString()
+	for i, hook := range in.Spec.RolloutHooks {
+		hookPath := hooksPath.Index(i)
+		if hook.Name == "" {
+			allErrs = append(allErrs, field.Required(hookPath.Child("name"), "must be set"))
+		} else if seenNames.Has(hook.Name) {
+			allErrs = append(allErrs, field.Duplicate(hookPath.Child("name"), hook.Name))
+		} else {
+			seenNames.Insert(hook.Name)
+		}
+
+		if hook.Endpoint == "" {
+			allErrs = append(allErrs, field.Required(hookPath.Child("endpoint"), "must be set"))
+		}
+
+		if hook.Default != "" && hook.Default != RolloutHookDefaultAllow && hook.Default != RolloutHookDefaultDeny {
+			allErrs = append(allErrs, field.NotSupported(hookPath.Child("default"), hook.Default,
+				[]string{string(RolloutHookDefaultAllow), string(RolloutHookDefaultDeny)}))
+		}
+
+		if hook.TimeoutSeconds != nil && *hook.TimeoutSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(hookPath.Child("timeoutSeconds"), *hook.TimeoutSeconds, "must be greater than 0"))
+		}
+	}
+
+	return allErrs
+}
+
+func aggregateFieldErrors(in *KubeadmControlPlane, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("KubeadmControlPlane").GroupKind(), in.Name, allErrs)
+}
+
+func maxSurgeZero(v *intstr.IntOrString) bool {
+	return v.Type == intstr.Int && v.IntVal == 0
+}
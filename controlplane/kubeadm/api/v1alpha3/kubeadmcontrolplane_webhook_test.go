@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestKubeadmControlPlaneDefaultRolloutHooks(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &KubeadmControlPlane{
+		Spec: KubeadmControlPlaneSpec{
+			RolloutHooks: []RolloutHook{{Name: "maintenance-window", Endpoint: "policy-sidecar:9443"}},
+		},
+	}
+
+	kcp.Default()
+
+	hook := kcp.Spec.RolloutHooks[0]
+	g.Expect(hook.TimeoutSeconds).To(PointTo(Equal(int32(5))))
+	g.Expect(hook.Default).To(Equal(RolloutHookDefaultDeny))
+}
+
+func TestKubeadmControlPlaneValidateRolloutHooks(t *testing.T) {
+	t.Run("rejects a hook with no name", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &KubeadmControlPlane{
+			Spec: KubeadmControlPlaneSpec{RolloutHooks: []RolloutHook{{Endpoint: "policy-sidecar:9443"}}},
+		}
+		g.Expect(kcp.ValidateCreate()).NotTo(Succeed())
+	})
+
+	t.Run("rejects a hook with no endpoint", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &KubeadmControlPlane{
+			Spec: KubeadmControlPlaneSpec{RolloutHooks: []RolloutHook{{Name: "maintenance-window"}}},
+		}
+		g.Expect(kcp.ValidateCreate()).NotTo(Succeed())
+	})
+
+	t.Run("rejects duplicate hook names", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &KubeadmControlPlane{
+			Spec: KubeadmControlPlaneSpec{RolloutHooks: []RolloutHook{
+				{Name: "dup", Endpoint: "a:9443"},
+				{Name: "dup", Endpoint: "b:9443"},
+			}},
+		}
+		g.Expect(kcp.ValidateCreate()).NotTo(Succeed())
+	})
+
+	t.Run("accepts a well formed hook", func(t *testing.T) {
+		g := NewWithT(t)
+		timeout := int32(10)
+		kcp := &KubeadmControlPlane{
+			Spec: KubeadmControlPlaneSpec{
+				RolloutStrategy: defaultRolloutStrategy(),
+				RolloutHooks: []RolloutHook{{
+					Name:           "maintenance-window",
+					Endpoint:       "policy-sidecar:9443",
+					TimeoutSeconds: &timeout,
+					Default:        RolloutHookDefaultAllow,
+				}},
+			},
+		}
+		g.Expect(kcp.ValidateCreate()).To(Succeed())
+	})
+}
+
+func TestKubeadmControlPlaneValidateRolloutStrategy(t *testing.T) {
+	t.Run("rejects canary set alongside RollingUpdate type", func(t *testing.T) {
+		g := NewWithT(t)
+		one := int32(1)
+		kcp := &KubeadmControlPlane{
+			Spec: KubeadmControlPlaneSpec{
+				RolloutStrategy: &RolloutStrategy{
+					Type:   RollingUpdateStrategyType,
+					Canary: &Canary{Replicas: &one},
+				},
+			},
+		}
+		g.Expect(kcp.ValidateCreate()).NotTo(Succeed())
+	})
+
+	t.Run("rejects maxSurge and maxUnavailable both zero", func(t *testing.T) {
+		g := NewWithT(t)
+		zero := intstr.FromInt(0)
+		kcp := &KubeadmControlPlane{
+			Spec: KubeadmControlPlaneSpec{
+				RolloutStrategy: &RolloutStrategy{
+					Type: RollingUpdateStrategyType,
+					RollingUpdate: &RollingUpdate{
+						MaxSurge:       &zero,
+						MaxUnavailable: &zero,
+					},
+				},
+			},
+		}
+		g.Expect(kcp.ValidateCreate()).NotTo(Succeed())
+	})
+
+	t.Run("accepts the default strategy", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &KubeadmControlPlane{Spec: KubeadmControlPlaneSpec{RolloutStrategy: defaultRolloutStrategy()}}
+		g.Expect(kcp.ValidateCreate()).To(Succeed())
+	})
+}
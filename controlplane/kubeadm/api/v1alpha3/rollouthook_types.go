@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import corev1 "k8s.io/api/core/v1"
+
+// RolloutHookDefaultDecision is the decision KCP assumes for a RolloutHook
+// when the hook cannot be reached before its deadline.
+type RolloutHookDefaultDecision string
+
+const (
+	// RolloutHookDefaultAllow lets KCP proceed with the rollout/remediation
+	// when the hook is unreachable.
+	RolloutHookDefaultAllow RolloutHookDefaultDecision = "Allow"
+
+	// RolloutHookDefaultDeny blocks the rollout/remediation when the hook is
+	// unreachable. This is the safer default for change-management gates.
+	RolloutHookDefaultDeny RolloutHookDefaultDecision = "Deny"
+)
+
+// RolloutHook configures an external gRPC service that KCP calls before
+// rolling out or remediating a control plane Machine, so that operator
+// policies (maintenance windows, fleet-wide quotas, change-management
+// approvals) can be enforced without forking CAPI.
+type RolloutHook struct {
+	// Name identifies this hook, used in logs, metrics and status conditions.
+	Name string `json:"name"`
+
+	// Endpoint is the address of the FilterService, e.g. "my-policy-sidecar:9443".
+	Endpoint string `json:"endpoint"`
+
+	// TLSSecretRef references a Secret of type kubernetes.io/tls (with an
+	// optional "ca.crt" key for verifying the server, and "tls.crt"/"tls.key"
+	// for mutual TLS) used to secure the connection to Endpoint. If nil, the
+	// connection is made without TLS.
+	// +optional
+	TLSSecretRef *corev1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+
+	// TimeoutSeconds bounds how long KCP waits for a response before falling
+	// back to Default. Defaults to 5.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Default is the decision KCP assumes when the hook does not respond
+	// within TimeoutSeconds or returns an error. Defaults to Deny.
+	// +optional
+	Default RolloutHookDefaultDecision `json:"default,omitempty"`
+}
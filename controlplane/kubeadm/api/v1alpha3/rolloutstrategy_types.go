@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// RolloutStrategyType defines the rollout strategies for a KubeadmControlPlane.
+type RolloutStrategyType string
+
+const (
+	// RollingUpdateStrategyType replaces out of date machines one (or a configurable
+	// batch) at a time, similar to a Deployment rolling update.
+	RollingUpdateStrategyType RolloutStrategyType = "RollingUpdate"
+
+	// CanaryStrategyType stands up a small number of machines running the new
+	// configuration alongside the existing control plane and promotes them to
+	// replace the rest of the fleet once Canary.PromoteAfter has elapsed.
+	CanaryStrategyType RolloutStrategyType = "Canary"
+)
+
+// RolloutStrategy describes how to replace existing machines with new ones.
+type RolloutStrategy struct {
+	// Type of rollout. Defaults to RollingUpdate.
+	// +optional
+	Type RolloutStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate is used to control the desired behavior of rolling update.
+	// +optional
+	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+
+	// Canary is used to control the desired behavior of a canary rollout.
+	// +optional
+	Canary *Canary `json:"canary,omitempty"`
+}
+
+// RollingUpdate is used to control the desired behavior of rolling update.
+type RollingUpdate struct {
+	// MaxUnavailable is the maximum number of control plane machines that can be
+	// unavailable during the update. Value can be an absolute number (ex: 1) or a
+	// percentage of desired machines (ex: 10%). Defaults to 0.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the maximum number of control plane machines that can be scheduled
+	// above the desired number of machines. Value can be an absolute number (ex: 1)
+	// or a percentage of desired machines (ex: 10%). Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// Canary is used to control the desired behavior of a canary rollout.
+type Canary struct {
+	// Replicas is the number of machines running the new configuration that
+	// are stood up alongside the existing control plane before promotion.
+	// Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PromoteAfter is the minimum amount of time since a canary machine's
+	// creation before it is eligible for promotion. Eligibility depends on this
+	// duration only; machine health and AnalysisRef are not consulted.
+	// +optional
+	PromoteAfter *metav1.Duration `json:"promoteAfter,omitempty"`
+
+	// AnalysisRef is an optional reference to an external object (for example
+	// an Argo AnalysisRun or a Flagger MetricTemplate) describing analysis of
+	// the canary machines. It is not read when deciding whether a canary
+	// machine is eligible for promotion.
+	// +optional
+	AnalysisRef *corev1.ObjectReference `json:"analysisRef,omitempty"`
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"context"
+	"sort"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MatchesKubeadmConfig returns a filter to find all machines whose KubeadmConfig
+// semantically matches kcp.Spec.KubeadmConfigSpec.ClusterConfiguration and
+// JoinConfiguration. Unlike
+// MatchesTemplateClonedFrom, which only compares cloned-from annotations, this
+// filter detects drift in fields like certSANs, the etcd image tag, feature gates
+// and apiServer extraArgs even when no one has bumped the infrastructure template.
+func MatchesKubeadmConfig(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		upToDate, err := kubeadmConfigUpToDate(ctx, c, kcp, machine)
+		if err != nil {
+			// A KubeadmConfig that is not found is not treated as out of date; any other
+			// error is. This is the same not-found tolerance as MatchesTemplateClonedFrom.
+			return apierrors.IsNotFound(err)
+		}
+		return upToDate
+	}
+}
+
+// kubeadmConfigUpToDate fetches the machine's KubeadmConfig, reports whether it
+// semantically matches the one desired by kcp, and records the result on the
+// machine's KubeadmConfigUpToDateCondition so the reason a rollout was triggered
+// is visible on the Machine itself.
+func kubeadmConfigUpToDate(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) (bool, error) {
+	diff, err := DiffKubeadmConfig(ctx, c, kcp, machine)
+	if err != nil {
+		return false, err
+	}
+	if diff != "" {
+		conditions.MarkFalse(machine, controlplanev1.KubeadmConfigUpToDateCondition, controlplanev1.KubeadmConfigOutOfDateReason, clusterv1.ConditionSeverityWarning, diff)
+		return false, nil
+	}
+	conditions.MarkTrue(machine, controlplanev1.KubeadmConfigUpToDateCondition)
+	return true, nil
+}
+
+// DiffKubeadmConfig fetches the KubeadmConfig referenced by machine's bootstrap config
+// and returns a human readable description of any semantic difference between its
+// ClusterConfiguration/JoinConfiguration and kcp's desired configuration. An empty
+// string means the two are equivalent. MatchesKubeadmConfig records the result on the
+// Machine's KubeadmConfigUpToDateCondition so users can see why a rollout is happening.
+func DiffKubeadmConfig(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) (string, error) {
+	configRef := machine.Spec.Bootstrap.ConfigRef
+	if configRef == nil {
+		return "", nil
+	}
+
+	machineConfig := &bootstrapv1.KubeadmConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: machine.Namespace, Name: configRef.Name}, machineConfig); err != nil {
+		return "", err
+	}
+
+	desiredCC := normalizeClusterConfiguration(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration)
+	actualCC := normalizeClusterConfiguration(machineConfig.Spec.ClusterConfiguration)
+	if !apiequality.Semantic.DeepEqual(desiredCC, actualCC) {
+		return "ClusterConfiguration differs from the KubeadmControlPlane's desired configuration", nil
+	}
+
+	desiredJC := normalizeJoinConfiguration(kcp.Spec.KubeadmConfigSpec.JoinConfiguration)
+	actualJC := normalizeJoinConfiguration(machineConfig.Spec.JoinConfiguration)
+	if !apiequality.Semantic.DeepEqual(desiredJC, actualJC) {
+		return "JoinConfiguration differs from the KubeadmControlPlane's desired configuration", nil
+	}
+
+	return "", nil
+}
+
+// normalizeClusterConfiguration returns a copy of cc with fields that are purely
+// representational (ordering) or that KCP itself mutates normalized away, so that
+// two semantically identical configurations compare equal regardless of map/slice
+// ordering or nil-vs-empty representation.
+func normalizeClusterConfiguration(cc *bootstrapv1.ClusterConfiguration) *bootstrapv1.ClusterConfiguration {
+	if cc == nil {
+		return &bootstrapv1.ClusterConfiguration{}
+	}
+	normalized := cc.DeepCopy()
+
+	sort.Strings(normalized.APIServer.CertSANs)
+
+	if len(normalized.APIServer.ExtraArgs) == 0 {
+		normalized.APIServer.ExtraArgs = nil
+	}
+	if len(normalized.ControllerManager.ExtraArgs) == 0 {
+		normalized.ControllerManager.ExtraArgs = nil
+	}
+	if len(normalized.Scheduler.ExtraArgs) == 0 {
+		normalized.Scheduler.ExtraArgs = nil
+	}
+	if len(normalized.FeatureGates) == 0 {
+		normalized.FeatureGates = nil
+	}
+
+	return normalized
+}
+
+// normalizeJoinConfiguration returns a copy of jc with fields that are purely
+// representational normalized away and LocalAPIEndpoint cleared, since KCP itself
+// fills that field in with the advertise address/port of the node the machine
+// joins and it is therefore never part of the desired configuration.
+func normalizeJoinConfiguration(jc *bootstrapv1.JoinConfiguration) *bootstrapv1.JoinConfiguration {
+	if jc == nil {
+		return &bootstrapv1.JoinConfiguration{}
+	}
+	normalized := jc.DeepCopy()
+
+	if normalized.ControlPlane != nil {
+		normalized.ControlPlane.LocalAPIEndpoint = bootstrapv1.APIEndpoint{}
+	}
+	if len(normalized.NodeRegistration.KubeletExtraArgs) == 0 {
+		normalized.NodeRegistration.KubeletExtraArgs = nil
+	}
+
+	return normalized
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/machinefilters"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func machineWithConfig(name string) (*clusterv1.Machine, *bootstrapv1.KubeadmConfig) {
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: clusterv1.MachineSpec{
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:      "KubeadmConfig",
+					Namespace: "default",
+					Name:      name + "-config",
+				},
+			},
+		},
+	}
+	config := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name + "-config"},
+	}
+	return machine, config
+}
+
+func TestMatchesKubeadmConfig(t *testing.T) {
+	t.Run("returns true when certSANs only differ in order", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						APIServer: bootstrapv1.APIServer{CertSANs: []string{"a", "b", "c"}},
+					},
+				},
+			},
+		}
+		machine, config := machineWithConfig("m1")
+		config.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			APIServer: bootstrapv1.APIServer{CertSANs: []string{"c", "a", "b"}},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, config)
+		g.Expect(machinefilters.MatchesKubeadmConfig(context.TODO(), client, kcp)(machine)).To(BeTrue())
+	})
+
+	t.Run("treats a nil and an empty extraArgs map as equivalent", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						APIServer: bootstrapv1.APIServer{ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{ExtraArgs: nil}},
+					},
+				},
+			},
+		}
+		machine, config := machineWithConfig("m2")
+		config.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			APIServer: bootstrapv1.APIServer{ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{ExtraArgs: map[string]string{}}},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, config)
+		g.Expect(machinefilters.MatchesKubeadmConfig(context.TODO(), client, kcp)(machine)).To(BeTrue())
+	})
+
+	t.Run("returns false when the etcd image tag drifts", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{ImageMeta: bootstrapv1.ImageMeta{ImageTag: "3.4.9-0"}}},
+					},
+				},
+			},
+		}
+		machine, config := machineWithConfig("m3")
+		config.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{ImageMeta: bootstrapv1.ImageMeta{ImageTag: "3.4.3-0"}}},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, config)
+		g.Expect(machinefilters.MatchesKubeadmConfig(context.TODO(), client, kcp)(machine)).To(BeFalse())
+	})
+
+	t.Run("ignores drift in fields KCP itself mutates, like JoinConfiguration.ControlPlane.LocalAPIEndpoint", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						APIServer: bootstrapv1.APIServer{CertSANs: []string{"a"}},
+					},
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						ControlPlane: &bootstrapv1.JoinControlPlane{
+							LocalAPIEndpoint: bootstrapv1.APIEndpoint{AdvertiseAddress: "10.0.0.1", BindPort: 6443},
+						},
+					},
+				},
+			},
+		}
+		machine, config := machineWithConfig("m4")
+		config.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			APIServer: bootstrapv1.APIServer{CertSANs: []string{"a"}},
+		}
+		config.Spec.JoinConfiguration = &bootstrapv1.JoinConfiguration{
+			ControlPlane: &bootstrapv1.JoinControlPlane{
+				LocalAPIEndpoint: bootstrapv1.APIEndpoint{AdvertiseAddress: "10.0.0.2", BindPort: 6443},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, config)
+		g.Expect(machinefilters.MatchesKubeadmConfig(context.TODO(), client, kcp)(machine)).To(BeTrue())
+	})
+
+	t.Run("returns false when JoinConfiguration drifts outside of LocalAPIEndpoint", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+							KubeletExtraArgs: map[string]string{"max-pods": "110"},
+						},
+					},
+				},
+			},
+		}
+		machine, config := machineWithConfig("m5")
+		config.Spec.JoinConfiguration = &bootstrapv1.JoinConfiguration{
+			NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+				KubeletExtraArgs: map[string]string{"max-pods": "220"},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, config)
+		g.Expect(machinefilters.MatchesKubeadmConfig(context.TODO(), client, kcp)(machine)).To(BeFalse())
+	})
+
+	t.Run("marks the Machine's KubeadmConfigUpToDateCondition with the diff reason", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{ImageMeta: bootstrapv1.ImageMeta{ImageTag: "3.4.9-0"}}},
+					},
+				},
+			},
+		}
+		machine, config := machineWithConfig("m6")
+		config.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{ImageMeta: bootstrapv1.ImageMeta{ImageTag: "3.4.3-0"}}},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, config)
+		g.Expect(machinefilters.MatchesKubeadmConfig(context.TODO(), client, kcp)(machine)).To(BeFalse())
+
+		condition := conditions.Get(machine, controlplanev1.KubeadmConfigUpToDateCondition)
+		g.Expect(condition).NotTo(BeNil())
+		g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmConfigOutOfDateReason))
+	})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+)
+
+const defaultExternalTimeout = 5 * time.Second
+
+// BuildTransportCredentials fetches the Secret referenced by secretRef (expected
+// to be of type kubernetes.io/tls, with "tls.crt"/"tls.key" keys and an optional
+// "ca.crt" key) from namespace, and returns gRPC transport credentials for
+// securing the connection to a RolloutHook's Endpoint. Without a "ca.crt" key,
+// the host's root CA set is used to verify the server. If secretRef is nil,
+// BuildTransportCredentials returns insecure credentials and the connection is
+// made without TLS.
+func BuildTransportCredentials(ctx context.Context, c client.Client, namespace string, secretRef *corev1.LocalObjectReference) (credentials.TransportCredentials, error) {
+	if secretRef == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretRef.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get TLS secret %s/%s for external rollout hook: %w", namespace, secretRef.Name, err)
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TLS key pair from secret %s/%s: %w", namespace, secretRef.Name, err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if ca := secret.Data["ca.crt"]; len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse ca.crt from secret %s/%s", namespace, secretRef.Name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Dial opens a gRPC connection to hook.Endpoint, secured with the transport
+// credentials built from hook.TLSSecretRef (or an insecure connection if
+// TLSSecretRef is nil). The returned connection is suitable for passing to
+// External, and should be closed by the caller once it is no longer needed.
+func Dial(ctx context.Context, c client.Client, namespace string, hook controlplanev1.RolloutHook) (*grpc.ClientConn, error) {
+	creds, err := BuildTransportCredentials(ctx, c, namespace, hook.TLSSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.NewClient(hook.Endpoint, grpc.WithTransportCredentials(creds))
+}
+
+// External returns a filter that dials the FilterService exposed by an operator
+// supplied sidecar through conn and asks it whether machine should be rolled or
+// remediated. needsRollout and isUnhealthy are passed through in the request so
+// the hook can make a policy decision informed by KCP's own assessment; hook
+// configures the per-hook timeout and fallback decision, and hook.Name identifies
+// this hook for logs and metrics. On any error, or if the deadline elapses,
+// External falls back to hook.Default rather than blocking KCP's reconcile loop.
+func External(conn grpc.ClientConnInterface, kcp *controlplanev1.KubeadmControlPlane, hook controlplanev1.RolloutHook, needsRollout, isUnhealthy Func) Func {
+	client := controlplanev1.NewFilterServiceClient(conn)
+
+	return func(machine *clusterv1.Machine) bool {
+		decision, reason := evaluate(client, kcp, hook, machine, needsRollout, isUnhealthy)
+		externalFilterDecisions.WithLabelValues(hook.Name, decision.String()).Inc()
+		if reason != "" {
+			klog.V(4).Infof("external rollout hook %q returned %s for machine %s: %s", hook.Name, decision, machine.Name, reason)
+		}
+		return decision == controlplanev1.Decision_DECISION_ALLOW
+	}
+}
+
+func evaluate(client controlplanev1.FilterServiceClient, kcp *controlplanev1.KubeadmControlPlane, hook controlplanev1.RolloutHook, machine *clusterv1.Machine, needsRollout, isUnhealthy Func) (controlplanev1.Decision, string) {
+	timeout := defaultExternalTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req := &controlplanev1.MachineFilterRequest{
+		ClusterNamespace:        kcp.Namespace,
+		KubeadmControlPlaneName: kcp.Name,
+		MachineName:             machine.Name,
+		NeedsRollout:            needsRollout != nil && needsRollout(machine),
+		IsUnhealthy:             isUnhealthy != nil && isUnhealthy(machine),
+	}
+
+	resp, err := client.Evaluate(ctx, req)
+	if err != nil {
+		return fallbackDecision(hook), err.Error()
+	}
+	if resp.Decision == controlplanev1.Decision_DECISION_UNSPECIFIED {
+		return fallbackDecision(hook), "hook returned an unspecified decision"
+	}
+	return resp.Decision, resp.Reason
+}
+
+func fallbackDecision(hook controlplanev1.RolloutHook) controlplanev1.Decision {
+	if hook.Default == controlplanev1.RolloutHookDefaultAllow {
+		return controlplanev1.Decision_DECISION_ALLOW
+	}
+	return controlplanev1.Decision_DECISION_DENY
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+)
+
+// fakeFilterServiceServer is a real FilterServiceServer implementation, used to
+// prove that MachineFilterRequest/MachineFilterResponse round-trip through an
+// actual gRPC codec rather than the hand-written FilterServiceClient used by
+// TestEvaluate in external_internal_test.go.
+type fakeFilterServiceServer struct {
+	controlplanev1.UnimplementedFilterServiceServer
+	resp *controlplanev1.MachineFilterResponse
+}
+
+func (f *fakeFilterServiceServer) Evaluate(ctx context.Context, req *controlplanev1.MachineFilterRequest) (*controlplanev1.MachineFilterResponse, error) {
+	return f.resp, nil
+}
+
+// dialFilterService starts an in-process gRPC server over a bufconn listener
+// and returns a client dialed against it. The server is stopped and the
+// connection closed through t.Cleanup when the test ends.
+func dialFilterService(t *testing.T, srv controlplanev1.FilterServiceServer) controlplanev1.FilterServiceClient {
+	t.Helper()
+	g := NewWithT(t)
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	controlplanev1.RegisterFilterServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return controlplanev1.NewFilterServiceClient(conn)
+}
+
+func TestEvaluateOverRealGRPC(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	machine := &clusterv1.Machine{}
+
+	g := NewWithT(t)
+	client := dialFilterService(t, &fakeFilterServiceServer{
+		resp: &controlplanev1.MachineFilterResponse{Decision: controlplanev1.Decision_DECISION_ALLOW, Reason: "because"},
+	})
+
+	decision, reason := evaluate(client, kcp, controlplanev1.RolloutHook{}, machine, nil, nil)
+	g.Expect(decision).To(Equal(controlplanev1.Decision_DECISION_ALLOW))
+	g.Expect(reason).To(Equal("because"))
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+)
+
+type fakeFilterServiceClient struct {
+	resp *controlplanev1.MachineFilterResponse
+	err  error
+}
+
+func (f *fakeFilterServiceClient) Evaluate(ctx context.Context, in *controlplanev1.MachineFilterRequest, opts ...grpc.CallOption) (*controlplanev1.MachineFilterResponse, error) {
+	return f.resp, f.err
+}
+
+func TestEvaluate(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	machine := &clusterv1.Machine{}
+
+	t.Run("returns the hook's decision on success", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &fakeFilterServiceClient{resp: &controlplanev1.MachineFilterResponse{Decision: controlplanev1.Decision_DECISION_ALLOW}}
+		decision, _ := evaluate(client, kcp, controlplanev1.RolloutHook{}, machine, nil, nil)
+		g.Expect(decision).To(Equal(controlplanev1.Decision_DECISION_ALLOW))
+	})
+
+	t.Run("falls back to the hook's default decision on error", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &fakeFilterServiceClient{err: errors.New("boom")}
+		hook := controlplanev1.RolloutHook{Default: controlplanev1.RolloutHookDefaultAllow}
+		decision, reason := evaluate(client, kcp, hook, machine, nil, nil)
+		g.Expect(decision).To(Equal(controlplanev1.Decision_DECISION_ALLOW))
+		g.Expect(reason).To(Equal("boom"))
+	})
+
+	t.Run("falls back to deny by default when unset", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &fakeFilterServiceClient{err: errors.New("boom")}
+		decision, _ := evaluate(client, kcp, controlplanev1.RolloutHook{}, machine, nil, nil)
+		g.Expect(decision).To(Equal(controlplanev1.Decision_DECISION_DENY))
+	})
+
+	t.Run("falls back when the hook returns an unspecified decision", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &fakeFilterServiceClient{resp: &controlplanev1.MachineFilterResponse{}}
+		hook := controlplanev1.RolloutHook{Default: controlplanev1.RolloutHookDefaultAllow}
+		decision, reason := evaluate(client, kcp, hook, machine, nil, nil)
+		g.Expect(decision).To(Equal(controlplanev1.Decision_DECISION_ALLOW))
+		g.Expect(reason).NotTo(BeEmpty())
+	})
+}
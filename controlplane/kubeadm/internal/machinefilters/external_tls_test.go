@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+)
+
+// selfSignedCert generates a self-signed certificate/key pair valid for
+// "127.0.0.1", returning their PEM encodings.
+func selfSignedCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	g := gomega.NewWithT(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rollout-hook"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM
+}
+
+// TestBuildTransportCredentialsAndDial proves that BuildTransportCredentials and
+// Dial round-trip real cert material end to end: the secret's key pair is used
+// both to serve and to verify a TLS listener, over an actual network connection.
+func TestBuildTransportCredentialsAndDial(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	certPEM, keyPEM := selfSignedCert(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rollout-hook-tls"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			"ca.crt":                certPEM,
+		},
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(gomega.Succeed())
+	fakeClient := fake.NewFakeClientWithScheme(scheme, secret)
+
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	grpcServer := grpc.NewServer()
+	controlplanev1.RegisterFilterServiceServer(grpcServer, &fakeFilterServiceServer{
+		resp: &controlplanev1.MachineFilterResponse{Decision: controlplanev1.Decision_DECISION_ALLOW, Reason: "tls works"},
+	})
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	hook := controlplanev1.RolloutHook{
+		Endpoint:     lis.Addr().String(),
+		TLSSecretRef: &corev1.LocalObjectReference{Name: "rollout-hook-tls"},
+	}
+
+	conn, err := Dial(context.TODO(), fakeClient, "default", hook)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := controlplanev1.NewFilterServiceClient(conn)
+	resp, err := client.Evaluate(context.Background(), &controlplanev1.MachineFilterRequest{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(resp.Decision).To(gomega.Equal(controlplanev1.Decision_DECISION_ALLOW))
+	g.Expect(resp.Reason).To(gomega.Equal("tls works"))
+}
+
+// TestBuildTransportCredentialsNilSecretRef proves that a RolloutHook with no
+// TLSSecretRef falls back to an insecure connection rather than erroring out.
+func TestBuildTransportCredentialsNilSecretRef(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	fakeClient := fake.NewFakeClientWithScheme(scheme)
+
+	creds, err := BuildTransportCredentials(context.TODO(), fakeClient, "default", nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(creds.Info().SecurityProtocol).To(gomega.Equal("insecure"))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// controlPlaneComponentConditions are the static pod and etcd member health conditions that
+// must be true for a control plane Machine to be considered healthy.
+var controlPlaneComponentConditions = []clusterv1.ConditionType{
+	controlplanev1.MachineAPIServerPodHealthyCondition,
+	controlplanev1.MachineControllerManagerPodHealthyCondition,
+	controlplanev1.MachineSchedulerPodHealthyCondition,
+	controlplanev1.MachineEtcdPodHealthyCondition,
+	controlplanev1.MachineEtcdMemberHealthyCondition,
+}
+
+// MatchesHealth returns a filter to find all machines that are healthy, based on the
+// readiness of the Node they host, the readiness of the static pods for the control
+// plane components running on them, and, if the infrastructure provider reports one,
+// the readiness of the underlying infrastructure machine.
+func MatchesHealth(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+
+		for _, condition := range controlPlaneComponentConditions {
+			if !conditions.IsTrue(machine, condition) {
+				return false
+			}
+		}
+
+		if machine.Status.NodeRef == nil {
+			return false
+		}
+		node := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKey{Name: machine.Status.NodeRef.Name}, node); err != nil {
+			return false
+		}
+		if !nodeIsReady(node) {
+			return false
+		}
+
+		infraObj, err := external.Get(ctx, c, &machine.Spec.InfrastructureRef, machine.Namespace)
+		if err != nil {
+			return false
+		}
+		return infraIsReady(infraObj)
+	}
+}
+
+// infraIsReady reports whether an infrastructure machine is ready, treating a
+// provider that doesn't set status.ready at all as non-blocking: most
+// infrastructure providers never implement this optional field, and a machine
+// should not be permanently reported unhealthy just because its provider has
+// no opinion on readiness. A provider that explicitly sets status.ready to
+// false is still honoured.
+func infraIsReady(infraObj *unstructured.Unstructured) bool {
+	ready, found, err := unstructured.NestedBool(infraObj.Object, "status", "ready")
+	if err != nil || !found {
+		return true
+	}
+	return ready
+}
+
+// IsUnhealthy returns a filter to find all machines that do not match MatchesHealth.
+// The KCP controller prefers replacing these machines before it touches out-of-date
+// but otherwise healthy machines.
+func IsUnhealthy(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane) Func {
+	return Not(MatchesHealth(ctx, c, kcp))
+}
+
+func nodeIsReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/machinefilters"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newHealthyMachine() *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "m1"},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "InfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+				Name:       "infra-m1",
+				Namespace:  "default",
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "node-1"},
+		},
+	}
+	for _, c := range []clusterv1.ConditionType{
+		controlplanev1.MachineAPIServerPodHealthyCondition,
+		controlplanev1.MachineControllerManagerPodHealthyCondition,
+		controlplanev1.MachineSchedulerPodHealthyCondition,
+		controlplanev1.MachineEtcdPodHealthyCondition,
+		controlplanev1.MachineEtcdMemberHealthyCondition,
+	} {
+		conditions.MarkTrue(m, c)
+	}
+	return m
+}
+
+func TestMatchesHealth(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	infra := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "InfrastructureMachine",
+			"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha3",
+			"metadata": map[string]interface{}{
+				"name":      "infra-m1",
+				"namespace": "default",
+			},
+			"status": map[string]interface{}{
+				"ready": true,
+			},
+		},
+	}
+
+	t.Run("returns true when node, static pods and infra machine are all healthy", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme := runtime.NewScheme()
+		g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, node, infra)
+		g.Expect(machinefilters.MatchesHealth(context.TODO(), client, kcp)(newHealthyMachine())).To(BeTrue())
+	})
+
+	t.Run("returns false when the etcd member health condition is missing", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme := runtime.NewScheme()
+		g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, node, infra)
+		m := newHealthyMachine()
+		conditions.Delete(m, controlplanev1.MachineEtcdMemberHealthyCondition)
+		g.Expect(machinefilters.MatchesHealth(context.TODO(), client, kcp)(m)).To(BeFalse())
+	})
+
+	t.Run("returns false when the node is not ready", func(t *testing.T) {
+		g := NewWithT(t)
+		notReadyNode := node.DeepCopy()
+		notReadyNode.Status.Conditions[0].Status = corev1.ConditionFalse
+		scheme := runtime.NewScheme()
+		g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, notReadyNode, infra)
+		g.Expect(machinefilters.MatchesHealth(context.TODO(), client, kcp)(newHealthyMachine())).To(BeFalse())
+	})
+
+	t.Run("returns false when the machine has no nodeRef", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme := runtime.NewScheme()
+		g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, node, infra)
+		m := newHealthyMachine()
+		m.Status.NodeRef = nil
+		g.Expect(machinefilters.MatchesHealth(context.TODO(), client, kcp)(m)).To(BeFalse())
+	})
+
+	t.Run("returns true when the infra machine reports no status at all", func(t *testing.T) {
+		g := NewWithT(t)
+		infraWithoutStatus := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       "InfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha3",
+				"metadata": map[string]interface{}{
+					"name":      "infra-m1",
+					"namespace": "default",
+				},
+			},
+		}
+		scheme := runtime.NewScheme()
+		g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, node, infraWithoutStatus)
+		g.Expect(machinefilters.MatchesHealth(context.TODO(), client, kcp)(newHealthyMachine())).To(BeTrue())
+	})
+
+	t.Run("returns false when the infra machine explicitly reports not ready", func(t *testing.T) {
+		g := NewWithT(t)
+		notReadyInfra := infra.DeepCopy()
+		notReadyInfra.Object["status"] = map[string]interface{}{"ready": false}
+		scheme := runtime.NewScheme()
+		g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewFakeClientWithScheme(scheme, node, notReadyInfra)
+		g.Expect(machinefilters.MatchesHealth(context.TODO(), client, kcp)(newHealthyMachine())).To(BeFalse())
+	})
+}
+
+func TestIsUnhealthy(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	scheme := runtime.NewScheme()
+	client := fake.NewFakeClientWithScheme(scheme)
+	m := newHealthyMachine()
+	m.Status.NodeRef = nil // not healthy: no node
+
+	g.Expect(machinefilters.IsUnhealthy(context.TODO(), client, kcp)(m)).To(BeTrue())
+}
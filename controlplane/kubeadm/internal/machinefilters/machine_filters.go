@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package machinefilters provides predicates, called filters, for filtering
+// machines that belong to a KubeadmControlPlane, and helpers to compose
+// those filters together.
+package machinefilters
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+)
+
+// Func is the functional definition of a filter.
+// The boolean return value indicates whether the machine matches the filter.
+type Func func(machine *clusterv1.Machine) bool
+
+// And returns a filter that returns true if all of the given filters returns true.
+func And(filters ...Func) Func {
+	return func(machine *clusterv1.Machine) bool {
+		for _, f := range filters {
+			if !f(machine) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a filter that returns true if any of the given filters returns true.
+func Or(filters ...Func) Func {
+	return func(machine *clusterv1.Machine) bool {
+		for _, f := range filters {
+			if f(machine) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a filter that returns the opposite of the given filter.
+func Not(mf Func) Func {
+	return func(machine *clusterv1.Machine) bool {
+		return !mf(machine)
+	}
+}
+
+// HasDeletionTimestamp applies to any machine that has a deletion timestamp set.
+func HasDeletionTimestamp(machine *clusterv1.Machine) bool {
+	if machine == nil {
+		return false
+	}
+	return !machine.DeletionTimestamp.IsZero()
+}
+
+// ShouldRolloutAfter returns a filter to find all machines that should be rolled out after a certain time.
+func ShouldRolloutAfter(reconciliationTime *metav1.Time) func(machine *clusterv1.Machine) bool {
+	return func(machine *clusterv1.Machine) bool {
+		if reconciliationTime == nil {
+			return false
+		}
+		if machine == nil {
+			return false
+		}
+		return machine.CreationTimestamp.Before(reconciliationTime)
+	}
+}
+
+// HasAnnotationKey returns a filter to find all machines that have an annotation with the given key.
+func HasAnnotationKey(key string) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		if _, ok := machine.GetAnnotations()[key]; ok {
+			return true
+		}
+		return false
+	}
+}
+
+// InFailureDomains returns a filter to find all machines that are in one of the given failure domains.
+func InFailureDomains(failureDomains ...*string) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		for i := range failureDomains {
+			fd := failureDomains[i]
+			if fd == nil {
+				if machine.Spec.FailureDomain == nil {
+					return true
+				}
+				continue
+			}
+			if machine.Spec.FailureDomain == nil {
+				continue
+			}
+			if *fd == *machine.Spec.FailureDomain {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchesKubernetesVersion returns a filter to find all machines that match a given Kubernetes version.
+func MatchesKubernetesVersion(kubernetesVersion string) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		if machine.Spec.Version == nil {
+			return false
+		}
+		return *machine.Spec.Version == kubernetesVersion
+	}
+}
+
+// MatchesTemplateClonedFrom returns a filter to find all machines that match a given KubeadmControlPlane's infra
+// template. It will return true if the machine's infrastructure ref is not found, on the assumption that the
+// machine is being reconciled and will eventually be updated, and false on any other error.
+func MatchesTemplateClonedFrom(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		infraObj, err := external.Get(ctx, c, &machine.Spec.InfrastructureRef, machine.Namespace)
+		if err != nil {
+			// Return true here because we don't want to delete or otherwise have an effect on a machine
+			// that we couldn't correctly determine the infrastructure template for.
+			if apierrors.IsNotFound(err) {
+				return true
+			}
+			return false
+		}
+
+		clonedFromName, ok1 := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+		clonedFromGroupKind, ok2 := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation]
+		if !ok1 || !ok2 {
+			// if the annotations are not found, then the machine is considered up to date.
+			return true
+		}
+
+		infraRef := kcp.Spec.InfrastructureTemplate
+		return clonedFromName == infraRef.Name &&
+			clonedFromGroupKind == infraRef.GroupVersionKind().GroupKind().String()
+	}
+}
+
+// CanaryMachineAnnotation is set on machines that were created as part of a
+// KubeadmControlPlane Canary rollout, before they have been promoted.
+const CanaryMachineAnnotation = "controlplane.cluster.x-k8s.io/canary"
+
+// NeedsRollout returns a filter to find all machines that are out of date with the
+// control plane's desired Kubernetes version or infrastructure template (compared by
+// cloned-from annotations), or that are due for a forced rollout per
+// kcp.Spec.UpgradeAfter. Drift in the machine's KubeadmConfig is not checked here;
+// MatchesKubeadmConfig covers that. Machines matching
+// this filter are candidates for the configured rollout strategy, regardless of
+// whether that strategy is RollingUpdate or Canary.
+func NeedsRollout(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane) Func {
+	return Or(
+		Not(MatchesKubernetesVersion(kcp.Spec.Version)),
+		Not(MatchesTemplateClonedFrom(ctx, c, kcp)),
+		ShouldRolloutAfter(kcp.Spec.UpgradeAfter),
+	)
+}
+
+// IsCanary returns a filter to find all machines that were created as part of an
+// in-progress Canary rollout and have not yet been promoted.
+func IsCanary(machine *clusterv1.Machine) bool {
+	if machine == nil {
+		return false
+	}
+	return HasAnnotationKey(CanaryMachineAnnotation)(machine)
+}
+
+// IsPromotable returns a filter to find all canary machines that were created at
+// least strategy.Canary.PromoteAfter ago and are therefore eligible to be
+// promoted to permanent control plane members. Non-canary machines never match.
+// Machine health and strategy.Canary.AnalysisRef are not consulted.
+func IsPromotable(strategy *controlplanev1.RolloutStrategy, now metav1.Time) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if !IsCanary(machine) {
+			return false
+		}
+		if strategy == nil || strategy.Canary == nil || strategy.Canary.PromoteAfter == nil {
+			return true
+		}
+		eligibleAt := metav1.NewTime(machine.CreationTimestamp.Add(strategy.Canary.PromoteAfter.Duration))
+		return !now.Before(&eligibleAt)
+	}
+}
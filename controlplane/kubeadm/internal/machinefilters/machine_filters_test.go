@@ -341,3 +341,51 @@ func TestMatchesTemplateClonedFrom_WithClonedFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestIsCanary(t *testing.T) {
+	t.Run("machine with the canary annotation returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		m.SetAnnotations(map[string]string{machinefilters.CanaryMachineAnnotation: ""})
+		g.Expect(machinefilters.IsCanary(m)).To(BeTrue())
+	})
+	t.Run("machine without the canary annotation returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(machinefilters.IsCanary(m)).To(BeFalse())
+	})
+}
+
+func TestIsPromotable(t *testing.T) {
+	t.Run("non-canary machine never matches", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(machinefilters.IsPromotable(nil, metav1.Now())(m)).To(BeFalse())
+	})
+	t.Run("canary machine matches immediately when no promoteAfter is set", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		m.SetAnnotations(map[string]string{machinefilters.CanaryMachineAnnotation: ""})
+		g.Expect(machinefilters.IsPromotable(nil, metav1.Now())(m)).To(BeTrue())
+	})
+	t.Run("canary machine is not promotable before promoteAfter has elapsed", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		m.SetAnnotations(map[string]string{machinefilters.CanaryMachineAnnotation: ""})
+		m.SetCreationTimestamp(metav1.Now())
+		strategy := &controlplanev1.RolloutStrategy{
+			Canary: &controlplanev1.Canary{PromoteAfter: &metav1.Duration{Duration: time.Hour}},
+		}
+		g.Expect(machinefilters.IsPromotable(strategy, metav1.Now())(m)).To(BeFalse())
+	})
+	t.Run("canary machine is promotable once promoteAfter has elapsed", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		m.SetAnnotations(map[string]string{machinefilters.CanaryMachineAnnotation: ""})
+		m.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-2 * time.Hour)))
+		strategy := &controlplanev1.RolloutStrategy{
+			Canary: &controlplanev1.Canary{PromoteAfter: &metav1.Duration{Duration: time.Hour}},
+		}
+		g.Expect(machinefilters.IsPromotable(strategy, metav1.Now())(m)).To(BeTrue())
+	})
+}
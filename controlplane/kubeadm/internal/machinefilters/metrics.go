@@ -0,0 +1,31 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinefilters
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var externalFilterDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "capi_kcp_external_filter_decisions_total",
+	Help: "Total number of decisions returned by external KCP rollout hooks, by hook name and decision.",
+}, []string{"hook", "decision"})
+
+func init() {
+	metrics.Registry.MustRegister(externalFilterDecisions)
+}
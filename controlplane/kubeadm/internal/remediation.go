@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/machinefilters"
+)
+
+// MachinesNeedingReplacement splits machines that need rollout into two ordered
+// groups: unhealthy machines first, then out-of-date-but-healthy machines. The
+// KCP controller replaces machines in this order so a failing control plane
+// component is remediated before the reconciler spends its rollout budget on a
+// routine version or template upgrade.
+func MachinesNeedingReplacement(machines []*clusterv1.Machine, isUnhealthy, needsRollout machinefilters.Func) (unhealthy, outOfDate []*clusterv1.Machine) {
+	for _, m := range machines {
+		switch {
+		case isUnhealthy(m):
+			unhealthy = append(unhealthy, m)
+		case needsRollout(m):
+			outOfDate = append(outOfDate, m)
+		}
+	}
+	return unhealthy, outOfDate
+}
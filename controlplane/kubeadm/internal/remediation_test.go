@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestMachinesNeedingReplacement(t *testing.T) {
+	g := NewWithT(t)
+
+	unhealthyMachine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy"}}
+	outOfDateMachine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "out-of-date"}}
+	upToDateMachine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "up-to-date"}}
+
+	isUnhealthy := func(m *clusterv1.Machine) bool { return m.Name == "unhealthy" }
+	needsRollout := func(m *clusterv1.Machine) bool { return m.Name == "out-of-date" }
+
+	unhealthy, outOfDate := MachinesNeedingReplacement(
+		[]*clusterv1.Machine{upToDateMachine, outOfDateMachine, unhealthyMachine},
+		isUnhealthy, needsRollout,
+	)
+
+	g.Expect(unhealthy).To(ConsistOf(unhealthyMachine))
+	g.Expect(outOfDate).To(ConsistOf(outOfDateMachine))
+}
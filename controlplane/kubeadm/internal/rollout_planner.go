@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/machinefilters"
+)
+
+// RolloutPlan describes how many machines the KCP reconciler should scale up
+// and scale down by this reconciliation pass in order to honour the control
+// plane's configured RolloutStrategy.
+type RolloutPlan struct {
+	// ScaleUpBy is the number of new machines that should be created this pass.
+	ScaleUpBy int
+
+	// ScaleDownBy is the number of out of date machines that should be deleted this pass.
+	ScaleDownBy int
+
+	// CanaryReplicas is the desired number of canary machines, only set for the Canary strategy.
+	CanaryReplicas int
+}
+
+// PlanRollout consults kcp.Spec.RolloutStrategy and returns how many machines
+// should be scaled up and down this reconciliation pass, given the number of
+// desired replicas and the number of machines that currently need rollout.
+func PlanRollout(kcp *controlplanev1.KubeadmControlPlane, desiredReplicas, currentReplicas, needsRolloutCount int) RolloutPlan {
+	strategy := kcp.Spec.RolloutStrategy
+	if strategy == nil || strategy.Type == "" || strategy.Type == controlplanev1.RollingUpdateStrategyType {
+		return planRollingUpdate(strategy, desiredReplicas, currentReplicas, needsRolloutCount)
+	}
+	return planCanary(strategy, desiredReplicas, currentReplicas, needsRolloutCount)
+}
+
+// PlanRolloutAndRemediation is the entry point a KCP reconcile loop calls each
+// pass: it first uses MachinesNeedingReplacement to split machines into those
+// that are unhealthy and those that are merely out of date, then consults
+// PlanRollout, using the total number of machines that need replacing, for how
+// many of them to act on this pass given kcp.Spec.RolloutStrategy. Remediating
+// unhealthy machines is always prioritised over routine version/template
+// upgrades, per MachinesNeedingReplacement's ordering.
+func PlanRolloutAndRemediation(kcp *controlplanev1.KubeadmControlPlane, desiredReplicas, currentReplicas int, machines []*clusterv1.Machine, isUnhealthy, needsRollout machinefilters.Func) (plan RolloutPlan, unhealthy, outOfDate []*clusterv1.Machine) {
+	unhealthy, outOfDate = MachinesNeedingReplacement(machines, isUnhealthy, needsRollout)
+	plan = PlanRollout(kcp, desiredReplicas, currentReplicas, len(unhealthy)+len(outOfDate))
+	return plan, unhealthy, outOfDate
+}
+
+func planRollingUpdate(strategy *controlplanev1.RolloutStrategy, desiredReplicas, currentReplicas, needsRolloutCount int) RolloutPlan {
+	if needsRolloutCount == 0 {
+		return RolloutPlan{}
+	}
+
+	maxSurge := 1
+	maxUnavailable := 0
+	if strategy != nil && strategy.RollingUpdate != nil {
+		if strategy.RollingUpdate.MaxSurge != nil {
+			maxSurge = intOrStringValue(strategy.RollingUpdate.MaxSurge, desiredReplicas, 1)
+		}
+		if strategy.RollingUpdate.MaxUnavailable != nil {
+			maxUnavailable = intOrStringValue(strategy.RollingUpdate.MaxUnavailable, desiredReplicas, 0)
+		}
+	}
+
+	maxTotal := desiredReplicas + maxSurge
+	scaleUpBy := maxTotal - currentReplicas
+	if scaleUpBy < 0 {
+		scaleUpBy = 0
+	}
+
+	minAvailable := desiredReplicas - maxUnavailable
+	scaleDownBy := currentReplicas - minAvailable
+	if scaleDownBy < 0 {
+		scaleDownBy = 0
+	}
+	if scaleDownBy > needsRolloutCount {
+		scaleDownBy = needsRolloutCount
+	}
+
+	return RolloutPlan{ScaleUpBy: scaleUpBy, ScaleDownBy: scaleDownBy}
+}
+
+func planCanary(strategy *controlplanev1.RolloutStrategy, desiredReplicas, currentReplicas, needsRolloutCount int) RolloutPlan {
+	if needsRolloutCount == 0 {
+		return RolloutPlan{}
+	}
+
+	canaryReplicas := 1
+	if strategy != nil && strategy.Canary != nil && strategy.Canary.Replicas != nil {
+		canaryReplicas = int(*strategy.Canary.Replicas)
+	}
+
+	if currentReplicas < desiredReplicas+canaryReplicas {
+		return RolloutPlan{ScaleUpBy: 1, CanaryReplicas: canaryReplicas}
+	}
+
+	return RolloutPlan{CanaryReplicas: canaryReplicas}
+}
+
+func intOrStringValue(v *intstr.IntOrString, total, fallback int) int {
+	if v == nil {
+		return fallback
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(v, total, true)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/machinefilters"
+)
+
+func TestPlanRolloutRollingUpdate(t *testing.T) {
+	g := NewWithT(t)
+	maxSurge := intstr.FromInt(1)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			RolloutStrategy: &controlplanev1.RolloutStrategy{
+				Type:          controlplanev1.RollingUpdateStrategyType,
+				RollingUpdate: &controlplanev1.RollingUpdate{MaxSurge: &maxSurge},
+			},
+		},
+	}
+
+	t.Run("nothing to do when no machine needs rollout", func(t *testing.T) {
+		plan := PlanRollout(kcp, 3, 3, 0)
+		g.Expect(plan).To(Equal(RolloutPlan{}))
+	})
+
+	t.Run("scales up by maxSurge before scaling down", func(t *testing.T) {
+		plan := PlanRollout(kcp, 3, 3, 1)
+		g.Expect(plan.ScaleUpBy).To(Equal(1))
+		g.Expect(plan.ScaleDownBy).To(Equal(0))
+	})
+
+	t.Run("scales down once the surge machine exists", func(t *testing.T) {
+		plan := PlanRollout(kcp, 3, 4, 1)
+		g.Expect(plan.ScaleUpBy).To(Equal(0))
+		g.Expect(plan.ScaleDownBy).To(Equal(1))
+	})
+
+	t.Run("a nil strategy defaults to RollingUpdate, not Canary", func(t *testing.T) {
+		noStrategyKCP := &controlplanev1.KubeadmControlPlane{}
+		plan := PlanRollout(noStrategyKCP, 3, 3, 1)
+		g.Expect(plan.ScaleUpBy).To(Equal(1))
+		g.Expect(plan.ScaleDownBy).To(Equal(0))
+		g.Expect(plan.CanaryReplicas).To(Equal(0))
+	})
+}
+
+func TestPlanRolloutCanary(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			RolloutStrategy: &controlplanev1.RolloutStrategy{
+				Type:   controlplanev1.CanaryStrategyType,
+				Canary: &controlplanev1.Canary{Replicas: pointer.Int32Ptr(2)},
+			},
+		},
+	}
+
+	t.Run("stands up canary replicas before touching the rest of the fleet", func(t *testing.T) {
+		plan := PlanRollout(kcp, 3, 3, 1)
+		g.Expect(plan.ScaleUpBy).To(Equal(1))
+		g.Expect(plan.ScaleDownBy).To(Equal(0))
+		g.Expect(plan.CanaryReplicas).To(Equal(2))
+	})
+
+	t.Run("does not scale down automatically, promotion is a separate step", func(t *testing.T) {
+		plan := PlanRollout(kcp, 3, 5, 1)
+		g.Expect(plan.ScaleUpBy).To(Equal(0))
+		g.Expect(plan.ScaleDownBy).To(Equal(0))
+	})
+}
+
+func TestPlanRolloutAndRemediation(t *testing.T) {
+	g := NewWithT(t)
+	maxSurge := intstr.FromInt(1)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			RolloutStrategy: &controlplanev1.RolloutStrategy{
+				Type:          controlplanev1.RollingUpdateStrategyType,
+				RollingUpdate: &controlplanev1.RollingUpdate{MaxSurge: &maxSurge},
+			},
+		},
+	}
+	healthy := &clusterv1.Machine{}
+	machines := []*clusterv1.Machine{healthy, {}, {}}
+	isUnhealthy := machinefilters.Func(func(m *clusterv1.Machine) bool { return m != healthy && len(machines) > 0 && m == machines[1] })
+	needsRollout := machinefilters.Func(func(m *clusterv1.Machine) bool { return m == machines[2] })
+
+	plan, unhealthy, outOfDate := PlanRolloutAndRemediation(kcp, 3, 3, machines, isUnhealthy, needsRollout)
+
+	g.Expect(unhealthy).To(ConsistOf(machines[1]))
+	g.Expect(outOfDate).To(ConsistOf(machines[2]))
+	g.Expect(plan.ScaleUpBy).To(Equal(1))
+	g.Expect(plan.ScaleDownBy).To(Equal(0))
+}